@@ -0,0 +1,278 @@
+package receivermock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// errNotFound404 signals that receiver-mock doesn't implement the
+// server-side aggregation endpoint, so the caller should fall back to
+// client-side computation.
+var errNotFound404 = errors.New("receiver-mock endpoint not found")
+
+// AggFunc is a server- or client-side aggregation applied to grouped metric
+// samples.
+type AggFunc string
+
+const (
+	AggCount AggFunc = "count"
+	AggSum   AggFunc = "sum"
+	AggMin   AggFunc = "min"
+	AggMax   AggFunc = "max"
+	AggAvg   AggFunc = "avg"
+	// AggRate computes the per-second derivative of the aggregated value
+	// over the observed timestamp window.
+	AggRate AggFunc = "rate"
+)
+
+// LabelKey identifies one group produced by GetMetricsAggregated: the
+// group-by label values joined in "key=value" pairs, sorted as strings so
+// equal groups always produce equal keys.
+type LabelKey string
+
+// AggResult is the result of applying an AggFunc to one group of samples.
+type AggResult struct {
+	Value      float64
+	SampleSize int
+}
+
+// labelKeyFor builds a stable LabelKey from the group-by labels of a sample.
+func labelKeyFor(labels Labels, groupBy []string) LabelKey {
+	pairs := make([]string, 0, len(groupBy))
+	for _, label := range groupBy {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", label, labels[label]))
+	}
+	sort.Strings(pairs)
+	return LabelKey(strings.Join(pairs, ","))
+}
+
+// GetMetricsAggregated groups metric samples matching filters by groupBy and
+// applies agg to each group. It prefers receiver-mock's server-side
+// metrics-aggregate endpoint, falling back to computing the aggregation
+// client-side over GetMetricsSamples when that endpoint isn't available
+// (404).
+func (client *ReceiverMockClient) GetMetricsAggregated(
+	filters MetadataFilters,
+	groupBy []string,
+	agg AggFunc,
+) (map[LabelKey]AggResult, error) {
+	result, err := client.getMetricsAggregatedServer(filters, groupBy, agg)
+	if err == nil {
+		return result, nil
+	}
+	if !errors.Is(err, errNotFound404) {
+		return nil, err
+	}
+	return client.getMetricsAggregatedClient(filters, groupBy, agg)
+}
+
+func (client *ReceiverMockClient) getMetricsAggregatedServer(
+	filters MetadataFilters,
+	groupBy []string,
+	agg AggFunc,
+) (map[LabelKey]AggResult, error) {
+	path, err := url.Parse("metrics-aggregate")
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing metrics-aggregate url: %w", err)
+	}
+	u := client.baseUrl.ResolveReference(path)
+
+	q := u.Query()
+	for k, v := range filters {
+		q.Add(k, v)
+	}
+	for _, label := range groupBy {
+		q.Add("group_by", label)
+	}
+	q.Add("agg", string(agg))
+	u.RawQuery = q.Encode()
+
+	resp, err := client.get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching %s, err: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return nil, fmt.Errorf("%w: %q", errNotFound404, u.String())
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf(
+			"received status code %d in response to receiver request at %q",
+			resp.StatusCode, u,
+		)
+	}
+
+	var raw map[string]AggResult
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	result := make(map[LabelKey]AggResult, len(raw))
+	for k, v := range raw {
+		result[LabelKey(k)] = v
+	}
+	return result, nil
+}
+
+func (client *ReceiverMockClient) getMetricsAggregatedClient(
+	filters MetadataFilters,
+	groupBy []string,
+	agg AggFunc,
+) (map[LabelKey]AggResult, error) {
+	samples, err := client.GetMetricsSamples(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[LabelKey][]MetricSample)
+	for _, sample := range samples {
+		key := labelKeyFor(sample.Labels, groupBy)
+		groups[key] = append(groups[key], sample)
+	}
+
+	result := make(map[LabelKey]AggResult, len(groups))
+	for key, groupSamples := range groups {
+		result[key] = applyAgg(agg, groupSamples)
+	}
+	return result, nil
+}
+
+func applyAgg(agg AggFunc, samples []MetricSample) AggResult {
+	res := AggResult{SampleSize: len(samples)}
+	if len(samples) == 0 {
+		return res
+	}
+
+	switch agg {
+	case AggCount:
+		res.Value = float64(len(samples))
+	case AggSum:
+		for _, s := range samples {
+			res.Value += s.Value
+		}
+	case AggMin:
+		res.Value = samples[0].Value
+		for _, s := range samples[1:] {
+			if s.Value < res.Value {
+				res.Value = s.Value
+			}
+		}
+	case AggMax:
+		res.Value = samples[0].Value
+		for _, s := range samples[1:] {
+			if s.Value > res.Value {
+				res.Value = s.Value
+			}
+		}
+	case AggAvg:
+		var sum float64
+		for _, s := range samples {
+			sum += s.Value
+		}
+		res.Value = sum / float64(len(samples))
+	case AggRate:
+		res.Value = rateOverWindow(samples)
+	}
+	return res
+}
+
+// rateOverWindow computes the per-second derivative of value over the
+// samples' observed timestamp window, using the earliest and latest samples
+// in the group.
+func rateOverWindow(samples []MetricSample) float64 {
+	earliest, latest := samples[0], samples[0]
+	for _, s := range samples {
+		if s.Timestamp < earliest.Timestamp {
+			earliest = s
+		}
+		if s.Timestamp > latest.Timestamp {
+			latest = s
+		}
+	}
+
+	windowSeconds := float64(latest.Timestamp-earliest.Timestamp) / 1000
+	if windowSeconds <= 0 {
+		return 0
+	}
+	return (latest.Value - earliest.Value) / windowSeconds
+}
+
+// GetDistinctLabelValues returns the distinct values observed for label
+// across metric samples matching filters. It prefers receiver-mock's
+// server-side distinct-values endpoint, falling back to computing it
+// client-side over GetMetricsSamples when that endpoint isn't available
+// (404).
+func (client *ReceiverMockClient) GetDistinctLabelValues(label string, filters MetadataFilters) ([]string, error) {
+	values, err := client.getDistinctLabelValuesServer(label, filters)
+	if err == nil {
+		return values, nil
+	}
+	if !errors.Is(err, errNotFound404) {
+		return nil, err
+	}
+	return client.getDistinctLabelValuesClient(label, filters)
+}
+
+func (client *ReceiverMockClient) getDistinctLabelValuesServer(label string, filters MetadataFilters) ([]string, error) {
+	path, err := url.Parse("metrics-label-values")
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing metrics-label-values url: %w", err)
+	}
+	u := client.baseUrl.ResolveReference(path)
+
+	q := u.Query()
+	for k, v := range filters {
+		q.Add(k, v)
+	}
+	q.Add("label", label)
+	u.RawQuery = q.Encode()
+
+	resp, err := client.get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching %s, err: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return nil, fmt.Errorf("%w: %q", errNotFound404, u.String())
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf(
+			"received status code %d in response to receiver request at %q",
+			resp.StatusCode, u,
+		)
+	}
+
+	var values []string
+	if err := json.NewDecoder(resp.Body).Decode(&values); err != nil {
+		return nil, err
+	}
+	sort.Strings(values)
+	return values, nil
+}
+
+func (client *ReceiverMockClient) getDistinctLabelValuesClient(label string, filters MetadataFilters) ([]string, error) {
+	samples, err := client.GetMetricsSamples(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	for _, sample := range samples {
+		if value, ok := sample.Labels[label]; ok {
+			seen[value] = struct{}{}
+		}
+	}
+
+	values := make([]string, 0, len(seen))
+	for value := range seen {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values, nil
+}
@@ -0,0 +1,91 @@
+package receivermock
+
+import "testing"
+
+func TestApplyAgg(t *testing.T) {
+	samples := []MetricSample{
+		{Value: 1, Timestamp: 1000},
+		{Value: 2, Timestamp: 2000},
+		{Value: 3, Timestamp: 3000},
+	}
+
+	tests := []struct {
+		agg  AggFunc
+		want float64
+	}{
+		{AggCount, 3},
+		{AggSum, 6},
+		{AggMin, 1},
+		{AggMax, 3},
+		{AggAvg, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.agg), func(t *testing.T) {
+			got := applyAgg(tt.agg, samples)
+			if got.Value != tt.want {
+				t.Errorf("applyAgg(%s) = %v, want %v", tt.agg, got.Value, tt.want)
+			}
+			if got.SampleSize != len(samples) {
+				t.Errorf("applyAgg(%s).SampleSize = %d, want %d", tt.agg, got.SampleSize, len(samples))
+			}
+		})
+	}
+}
+
+func TestApplyAggEmpty(t *testing.T) {
+	got := applyAgg(AggSum, nil)
+	if got.Value != 0 || got.SampleSize != 0 {
+		t.Errorf("applyAgg on empty input = %+v, want zero value", got)
+	}
+}
+
+func TestRateOverWindow(t *testing.T) {
+	// Value increases by 10 over a 5 second window (timestamps in ms).
+	samples := []MetricSample{
+		{Value: 100, Timestamp: 1000},
+		{Value: 110, Timestamp: 6000},
+	}
+
+	got := rateOverWindow(samples)
+	want := 2.0 // 10 units / 5 seconds
+	if got != want {
+		t.Errorf("rateOverWindow() = %v, want %v", got, want)
+	}
+}
+
+func TestRateOverWindowUnorderedSamples(t *testing.T) {
+	// Earliest/latest should be resolved by timestamp, not slice order.
+	samples := []MetricSample{
+		{Value: 110, Timestamp: 6000},
+		{Value: 100, Timestamp: 1000},
+	}
+
+	got := rateOverWindow(samples)
+	want := 2.0
+	if got != want {
+		t.Errorf("rateOverWindow() = %v, want %v", got, want)
+	}
+}
+
+func TestRateOverWindowZeroWindow(t *testing.T) {
+	samples := []MetricSample{
+		{Value: 100, Timestamp: 1000},
+		{Value: 110, Timestamp: 1000},
+	}
+
+	if got := rateOverWindow(samples); got != 0 {
+		t.Errorf("rateOverWindow() with zero window = %v, want 0", got)
+	}
+}
+
+func TestLabelKeyFor(t *testing.T) {
+	labels := Labels{"namespace": "kube-system", "pod": "receiver-mock-0"}
+
+	// Group-by order shouldn't affect the resulting key.
+	a := labelKeyFor(labels, []string{"namespace", "pod"})
+	b := labelKeyFor(labels, []string{"pod", "namespace"})
+	if a != b {
+		t.Errorf("labelKeyFor should be order-independent: %q != %q", a, b)
+	}
+}
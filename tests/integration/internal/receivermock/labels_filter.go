@@ -0,0 +1,63 @@
+package receivermock
+
+import (
+	"path"
+	"strings"
+)
+
+// SampleQuery describes a metrics-samples request against receiver-mock,
+// combining the existing metadata filters with label-level include/exclude
+// globs modeled after the kubelet input plugin's label_include/label_exclude
+// options.
+//
+// LabelInclude and LabelExclude entries are glob patterns matched against
+// label keys with path.Match semantics (so "app.kubernetes.io/*" matches
+// only that one path segment). A pattern prefixed with "!" negates the
+// match, letting callers carve out an exception within an otherwise broad
+// include/exclude list.
+type SampleQuery struct {
+	Filters      MetadataFilters
+	LabelInclude []string
+	LabelExclude []string
+}
+
+// matchesGlobList reports whether key matches at least one non-negated
+// pattern in patterns and no negated ("!"-prefixed) pattern.
+func matchesGlobList(key string, patterns []string) bool {
+	matched := false
+	for _, pattern := range patterns {
+		negate := strings.HasPrefix(pattern, "!")
+		glob := strings.TrimPrefix(pattern, "!")
+
+		ok, err := path.Match(glob, key)
+		if err != nil || !ok {
+			continue
+		}
+		if negate {
+			return false
+		}
+		matched = true
+	}
+	return matched
+}
+
+// filterLabels applies include/exclude glob patterns to labels as a
+// client-side safety net, in case receiver-mock doesn't honor the
+// corresponding query parameters.
+func filterLabels(labels Labels, include, exclude []string) Labels {
+	if len(include) == 0 && len(exclude) == 0 {
+		return labels
+	}
+
+	filtered := make(Labels, len(labels))
+	for key, value := range labels {
+		if len(include) > 0 && !matchesGlobList(key, include) {
+			continue
+		}
+		if len(exclude) > 0 && matchesGlobList(key, exclude) {
+			continue
+		}
+		filtered[key] = value
+	}
+	return filtered
+}
@@ -0,0 +1,63 @@
+package receivermock
+
+import "testing"
+
+func TestMatchesGlobList(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		patterns []string
+		want     bool
+	}{
+		{"empty patterns", "app.kubernetes.io/name", nil, false},
+		{"exact match", "pod", []string{"pod"}, true},
+		{"glob match", "app.kubernetes.io/name", []string{"app.kubernetes.io/*"}, true},
+		{"glob no match across segments", "app.kubernetes.io/name", []string{"app.*"}, false},
+		{"no match", "internal.revision", []string{"app.kubernetes.io/*"}, false},
+		{"negated pattern overrides match", "internal.revision", []string{"*", "!internal.*"}, false},
+		{"negated pattern does not affect other keys", "app.kubernetes.io/name", []string{"app.kubernetes.io/*", "!internal.*"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesGlobList(tt.key, tt.patterns); got != tt.want {
+				t.Errorf("matchesGlobList(%q, %v) = %v, want %v", tt.key, tt.patterns, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterLabels(t *testing.T) {
+	labels := Labels{
+		"app.kubernetes.io/name": "receiver-mock",
+		"internal.revision":      "abc123",
+		"pod":                    "receiver-mock-0",
+	}
+
+	t.Run("no patterns returns labels unchanged", func(t *testing.T) {
+		got := filterLabels(labels, nil, nil)
+		if len(got) != len(labels) {
+			t.Fatalf("expected %d labels, got %d", len(labels), len(got))
+		}
+	})
+
+	t.Run("include keeps only matching keys", func(t *testing.T) {
+		got := filterLabels(labels, []string{"app.kubernetes.io/*"}, nil)
+		if len(got) != 1 {
+			t.Fatalf("expected 1 label, got %d: %v", len(got), got)
+		}
+		if _, ok := got["app.kubernetes.io/name"]; !ok {
+			t.Fatalf("expected app.kubernetes.io/name to survive filtering, got %v", got)
+		}
+	})
+
+	t.Run("exclude drops matching keys", func(t *testing.T) {
+		got := filterLabels(labels, nil, []string{"internal.*"})
+		if _, ok := got["internal.revision"]; ok {
+			t.Fatalf("expected internal.revision to be excluded, got %v", got)
+		}
+		if len(got) != 2 {
+			t.Fatalf("expected 2 labels, got %d: %v", len(got), got)
+		}
+	})
+}
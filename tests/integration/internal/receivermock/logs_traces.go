@@ -0,0 +1,145 @@
+package receivermock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// ResourceAttributes is a flat map of resource-level attributes (e.g. pod,
+// namespace, source) attached to a log record or span.
+type ResourceAttributes map[string]string
+
+// LogRecord is a single log record as returned by receiver-mock's /logs endpoint.
+type LogRecord struct {
+	Message    string             `json:"message,omitempty"`
+	Severity   string             `json:"severity,omitempty"`
+	Timestamp  uint64             `json:"timestamp,omitempty"`
+	Resource   ResourceAttributes `json:"resource,omitempty"`
+	Attributes Labels             `json:"attributes,omitempty"`
+}
+
+// LogRecordsByTime sorts LogRecords in descending order of Timestamp,
+// mirroring MetricsSamplesByTime.
+type LogRecordsByTime []LogRecord
+
+func (l LogRecordsByTime) Len() int           { return len(l) }
+func (l LogRecordsByTime) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
+func (l LogRecordsByTime) Less(i, j int) bool { return l[i].Timestamp > l[j].Timestamp }
+
+// Span is a single trace span as returned by receiver-mock's /spans endpoint.
+type Span struct {
+	TraceID    string             `json:"traceId,omitempty"`
+	SpanID     string             `json:"spanId,omitempty"`
+	Name       string             `json:"name,omitempty"`
+	Timestamp  uint64             `json:"timestamp,omitempty"`
+	Resource   ResourceAttributes `json:"resource,omitempty"`
+	Attributes Labels             `json:"attributes,omitempty"`
+}
+
+// SpansByTime sorts Spans in descending order of Timestamp, mirroring
+// MetricsSamplesByTime.
+type SpansByTime []Span
+
+func (s SpansByTime) Len() int           { return len(s) }
+func (s SpansByTime) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s SpansByTime) Less(i, j int) bool { return s[i].Timestamp > s[j].Timestamp }
+
+// GetLogs fetches log records from receiver-mock's /logs endpoint, applying
+// metadataFilters as query parameters.
+func (client *ReceiverMockClient) GetLogs(metadataFilters MetadataFilters) ([]LogRecord, error) {
+	path, err := url.Parse("logs")
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing logs url: %w", err)
+	}
+	u := client.baseUrl.ResolveReference(path)
+
+	q := u.Query()
+	for k, v := range metadataFilters {
+		q.Add(k, v)
+	}
+	u.RawQuery = q.Encode()
+
+	resp, err := client.get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching %s, err: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf(
+			"received status code %d in response to receiver request at %q",
+			resp.StatusCode, u,
+		)
+	}
+
+	var logRecords []LogRecord
+	if err := json.NewDecoder(resp.Body).Decode(&logRecords); err != nil {
+		return nil, err
+	}
+	return logRecords, nil
+}
+
+// GetLogCounts fetches the number of log records observed, keyed by
+// source/pod/namespace, from receiver-mock's /logs-count endpoint.
+func (client *ReceiverMockClient) GetLogCounts() (map[string]int, error) {
+	path, err := url.Parse("logs-count")
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing logs-count url: %w", err)
+	}
+	u := client.baseUrl.ResolveReference(path)
+
+	resp, err := client.get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching %s, err: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf(
+			"received status code %d in response to receiver request at %q",
+			resp.StatusCode, u,
+		)
+	}
+
+	var logCounts map[string]int
+	if err := json.NewDecoder(resp.Body).Decode(&logCounts); err != nil {
+		return nil, err
+	}
+	return logCounts, nil
+}
+
+// GetSpans fetches trace spans from receiver-mock's /spans endpoint, applying
+// metadataFilters as query parameters.
+func (client *ReceiverMockClient) GetSpans(metadataFilters MetadataFilters) ([]Span, error) {
+	path, err := url.Parse("spans")
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing spans url: %w", err)
+	}
+	u := client.baseUrl.ResolveReference(path)
+
+	q := u.Query()
+	for k, v := range metadataFilters {
+		q.Add(k, v)
+	}
+	u.RawQuery = q.Encode()
+
+	resp, err := client.get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching %s, err: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf(
+			"received status code %d in response to receiver request at %q",
+			resp.StatusCode, u,
+		)
+	}
+
+	var spans []Span
+	if err := json.NewDecoder(resp.Body).Decode(&spans); err != nil {
+		return nil, err
+	}
+	return spans, nil
+}
@@ -0,0 +1,92 @@
+package receivermock
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"testing"
+)
+
+func TestLogRecordsByTimeSortsDescending(t *testing.T) {
+	records := []LogRecord{
+		{Message: "a", Timestamp: 100},
+		{Message: "b", Timestamp: 300},
+		{Message: "c", Timestamp: 200},
+	}
+
+	sort.Sort(LogRecordsByTime(records))
+
+	want := []string{"b", "c", "a"}
+	for i, msg := range want {
+		if records[i].Message != msg {
+			t.Errorf("records[%d].Message = %q, want %q (got order %v)", i, records[i].Message, msg, records)
+		}
+	}
+}
+
+func TestSpansByTimeSortsDescending(t *testing.T) {
+	spans := []Span{
+		{Name: "a", Timestamp: 100},
+		{Name: "b", Timestamp: 300},
+		{Name: "c", Timestamp: 200},
+	}
+
+	sort.Sort(SpansByTime(spans))
+
+	want := []string{"b", "c", "a"}
+	for i, name := range want {
+		if spans[i].Name != name {
+			t.Errorf("spans[%d].Name = %q, want %q (got order %v)", i, spans[i].Name, name, spans)
+		}
+	}
+}
+
+func TestGetLogsSendsMetadataFiltersAsQueryParams(t *testing.T) {
+	var gotQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query()
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("failed parsing test server URL: %v", err)
+	}
+	client := NewClient(t, *u)
+
+	if _, err := client.GetLogs(MetadataFilters{"namespace": "kube-system", "pod": "receiver-mock-0"}); err != nil {
+		t.Fatalf("GetLogs returned unexpected error: %v", err)
+	}
+
+	if got := gotQuery.Get("namespace"); got != "kube-system" {
+		t.Errorf("query param namespace = %q, want %q", got, "kube-system")
+	}
+	if got := gotQuery.Get("pod"); got != "receiver-mock-0" {
+		t.Errorf("query param pod = %q, want %q", got, "receiver-mock-0")
+	}
+}
+
+func TestGetSpansSendsMetadataFiltersAsQueryParams(t *testing.T) {
+	var gotQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query()
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("failed parsing test server URL: %v", err)
+	}
+	client := NewClient(t, *u)
+
+	if _, err := client.GetSpans(MetadataFilters{"traceId": "abc123"}); err != nil {
+		t.Fatalf("GetSpans returned unexpected error: %v", err)
+	}
+
+	if got := gotQuery.Get("traceId"); got != "abc123" {
+		t.Errorf("query param traceId = %q, want %q", got, "abc123")
+	}
+}
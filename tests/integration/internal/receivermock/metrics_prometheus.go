@@ -0,0 +1,176 @@
+package receivermock
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// MetricType mirrors the Prometheus metric types we care about when parsing
+// the exposition format.
+type MetricType string
+
+const (
+	MetricTypeCounter   MetricType = "counter"
+	MetricTypeGauge     MetricType = "gauge"
+	MetricTypeHistogram MetricType = "histogram"
+	MetricTypeSummary   MetricType = "summary"
+	MetricTypeUntyped   MetricType = "untyped"
+)
+
+// Metric is a single labeled sample belonging to a PromMetricFamily.
+type Metric struct {
+	Labels Labels
+	Value  float64
+}
+
+// PromMetricFamily is a parsed Prometheus metric family, preserving its help
+// text, type, and its labeled counter, gauge and untyped samples. A histogram
+// contributes one sample per bucket (labeled "le"); its _sum and _count are
+// not kept. A summary keeps its name, help and type but no samples.
+type PromMetricFamily struct {
+	Name    string
+	Help    string
+	Type    MetricType
+	Metrics []Metric
+}
+
+// PromMetricSet is the full set of metric families parsed from a Prometheus
+// exposition response, along with helpers for label-aware assertions.
+type PromMetricSet map[string]PromMetricFamily
+
+// GetMetricsPrometheus fetches the Prometheus exposition-format payload from
+// receiver-mock's metrics endpoint and parses it with expfmt, preserving
+// labels, metric type and help text that the legacy colon-split
+// parseMetricList discards.
+func (client *ReceiverMockClient) GetMetricsPrometheus(t *testing.T) (PromMetricSet, error) {
+	path, err := url.Parse("metrics")
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing metrics url: %w", err)
+	}
+	u := client.baseUrl.ResolveReference(path)
+
+	resp, err := client.get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching %s, err: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf(
+			"received status code %d in response to receiver request at %q",
+			resp.StatusCode, u,
+		)
+	}
+
+	var parser expfmt.TextParser
+	parsedFamilies, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing prometheus metrics from %q: %w", u, err)
+	}
+
+	metricSet := make(PromMetricSet, len(parsedFamilies))
+	for name, family := range parsedFamilies {
+		metricSet[name] = toPromMetricFamily(name, family)
+	}
+	return metricSet, nil
+}
+
+func toPromMetricFamily(name string, family *dto.MetricFamily) PromMetricFamily {
+	promFamily := PromMetricFamily{
+		Name: name,
+		Help: family.GetHelp(),
+		Type: metricTypeFromDto(family.GetType()),
+	}
+
+	for _, m := range family.GetMetric() {
+		labels := make(Labels, len(m.GetLabel()))
+		for _, labelPair := range m.GetLabel() {
+			labels[labelPair.GetName()] = labelPair.GetValue()
+		}
+
+		switch {
+		case m.Counter != nil:
+			promFamily.Metrics = append(promFamily.Metrics, Metric{Labels: labels, Value: m.GetCounter().GetValue()})
+		case m.Gauge != nil:
+			promFamily.Metrics = append(promFamily.Metrics, Metric{Labels: labels, Value: m.GetGauge().GetValue()})
+		case m.Histogram != nil:
+			for _, bucket := range m.GetHistogram().GetBucket() {
+				bucketLabels := make(Labels, len(labels)+1)
+				for k, v := range labels {
+					bucketLabels[k] = v
+				}
+				bucketLabels["le"] = fmt.Sprintf("%g", bucket.GetUpperBound())
+				promFamily.Metrics = append(promFamily.Metrics, Metric{Labels: bucketLabels, Value: float64(bucket.GetCumulativeCount())})
+			}
+		case m.Untyped != nil:
+			promFamily.Metrics = append(promFamily.Metrics, Metric{Labels: labels, Value: m.GetUntyped().GetValue()})
+		}
+	}
+	return promFamily
+}
+
+func metricTypeFromDto(t dto.MetricType) MetricType {
+	switch t {
+	case dto.MetricType_COUNTER:
+		return MetricTypeCounter
+	case dto.MetricType_GAUGE:
+		return MetricTypeGauge
+	case dto.MetricType_HISTOGRAM:
+		return MetricTypeHistogram
+	case dto.MetricType_SUMMARY:
+		return MetricTypeSummary
+	default:
+		return MetricTypeUntyped
+	}
+}
+
+// CountByName returns the number of samples observed across all label
+// combinations for the given metric name.
+func (s PromMetricSet) CountByName(name string) int {
+	return len(s[name].Metrics)
+}
+
+// CountByLabelSet returns the number of samples, across every metric family
+// in the set, whose labels contain every key/value pair in labelSet.
+func (s PromMetricSet) CountByLabelSet(labelSet map[string]string) int {
+	count := 0
+	for _, family := range s {
+		for _, m := range family.Metrics {
+			if m.Labels.Matches(labelSet) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// HistogramBuckets returns the cumulative bucket counts, keyed by the "le"
+// label, for the histogram metric family name.
+func (s PromMetricSet) HistogramBuckets(name string) map[string]float64 {
+	family, ok := s[name]
+	if !ok || family.Type != MetricTypeHistogram {
+		return nil
+	}
+
+	buckets := make(map[string]float64, len(family.Metrics))
+	for _, m := range family.Metrics {
+		if le, ok := m.Labels["le"]; ok {
+			buckets[le] = m.Value
+		}
+	}
+	return buckets
+}
+
+// Matches reports whether labels contains every key/value pair in subset.
+func (labels Labels) Matches(subset map[string]string) bool {
+	for k, v := range subset {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package receivermock
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/common/expfmt"
+)
+
+const samplePrometheusText = `
+# HELP http_requests_total The total number of HTTP requests.
+# TYPE http_requests_total counter
+http_requests_total{method="get",code="200"} 1027
+http_requests_total{method="post",code="500"} 3
+# HELP request_duration_seconds A histogram of request durations.
+# TYPE request_duration_seconds histogram
+request_duration_seconds_bucket{le="0.1"} 10
+request_duration_seconds_bucket{le="0.5"} 25
+request_duration_seconds_bucket{le="+Inf"} 30
+`
+
+func parseSampleFamily(t *testing.T, name string) PromMetricFamily {
+	t.Helper()
+	var parser expfmt.TextParser
+	parsed, err := parser.TextToMetricFamilies(strings.NewReader(samplePrometheusText))
+	if err != nil {
+		t.Fatalf("failed parsing sample prometheus text: %v", err)
+	}
+	family, ok := parsed[name]
+	if !ok {
+		t.Fatalf("expected family %q in parsed output", name)
+	}
+	return toPromMetricFamily(name, family)
+}
+
+func TestToPromMetricFamilyCounter(t *testing.T) {
+	family := parseSampleFamily(t, "http_requests_total")
+
+	if family.Type != MetricTypeCounter {
+		t.Errorf("expected type counter, got %v", family.Type)
+	}
+	if family.Help != "The total number of HTTP requests." {
+		t.Errorf("unexpected help text: %q", family.Help)
+	}
+	if len(family.Metrics) != 2 {
+		t.Fatalf("expected 2 metrics, got %d", len(family.Metrics))
+	}
+
+	metricSet := PromMetricSet{"http_requests_total": family}
+	if got := metricSet.CountByLabelSet(map[string]string{"method": "get"}); got != 1 {
+		t.Errorf("expected 1 sample for method=get, got %d", got)
+	}
+	if got := metricSet.CountByName("http_requests_total"); got != 2 {
+		t.Errorf("expected CountByName to report 2, got %d", got)
+	}
+}
+
+func TestToPromMetricFamilyHistogram(t *testing.T) {
+	family := parseSampleFamily(t, "request_duration_seconds")
+
+	if family.Type != MetricTypeHistogram {
+		t.Errorf("expected type histogram, got %v", family.Type)
+	}
+
+	metricSet := PromMetricSet{"request_duration_seconds": family}
+	buckets := metricSet.HistogramBuckets("request_duration_seconds")
+	if buckets["0.1"] != 10 {
+		t.Errorf("expected le=0.1 bucket to be 10, got %v", buckets["0.1"])
+	}
+	if buckets["0.5"] != 25 {
+		t.Errorf("expected le=0.5 bucket to be 25, got %v", buckets["0.5"])
+	}
+}
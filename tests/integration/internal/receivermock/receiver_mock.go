@@ -5,14 +5,12 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"io"
 	"net/url"
 	"strconv"
 	"strings"
 	"testing"
 
-	http_helper "github.com/gruntwork-io/terratest/modules/http-helper"
-
 	"github.com/SumoLogic/sumologic-kubernetes-collection/tests/integration/internal/k8s"
 )
 
@@ -23,10 +21,11 @@ type MetricCounts map[string]int
 type ReceiverMockClient struct {
 	baseUrl   url.URL
 	tlsConfig tls.Config
+	retry     retryConfig
 }
 
 func NewClient(t *testing.T, baseUrl url.URL) *ReceiverMockClient {
-	return &ReceiverMockClient{baseUrl: baseUrl, tlsConfig: tls.Config{}}
+	return &ReceiverMockClient{baseUrl: baseUrl, tlsConfig: tls.Config{}, retry: defaultRetryConfig}
 }
 
 // NewClientWithK8sTunnel creates a client for receiver-mock.
@@ -46,31 +45,71 @@ func NewClientWithK8sTunnel(
 	return &ReceiverMockClient{
 			baseUrl:   baseUrl,
 			tlsConfig: tls.Config{},
+			retry:     defaultRetryConfig,
 		}, func() {
 			tunnel.Close()
 		}
 }
 
+// GetMetricCounts returns the number of times each metric has been observed,
+// as tracked by receiver-mock's /metrics-list endpoint. Unlike
+// GetMetricsPrometheus, which reflects a point-in-time scrape and so cannot
+// distinguish "one sample" from "the same sample observed N times with
+// identical labels", /metrics-list is itself a running per-metric counter -
+// this is the only endpoint that preserves the "number of times observed"
+// contract callers rely on.
 func (client *ReceiverMockClient) GetMetricCounts(t *testing.T) (MetricCounts, error) {
 	path, err := url.Parse("metrics-list")
 	if err != nil {
-		t.Fatal(err)
+		return nil, fmt.Errorf("failed parsing metrics-list url: %w", err)
+	}
+	u := client.baseUrl.ResolveReference(path)
+
+	resp, err := client.get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching %s, err: %w", u, err)
 	}
-	url := client.baseUrl.ResolveReference(path)
-
-	statusCode, body := http_helper.HttpGet(
-		t,
-		url.String(),
-		&client.tlsConfig,
-	)
-	if statusCode != 200 {
-		return nil, fmt.Errorf("received status code %d in response to receiver request", statusCode)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf(
+			"received status code %d in response to receiver request at %q",
+			resp.StatusCode, u,
+		)
 	}
-	metricCounts, err := parseMetricList(body)
+
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		t.Fatal(err)
+		return nil, err
 	}
-	return metricCounts, nil
+
+	return parseMetricList(string(body))
+}
+
+// parseMetricList parses the plain-text payload returned by /metrics-list:
+// one "<metric name>:<count>" line per metric.
+// https://github.com/SumoLogic/sumologic-kubernetes-tools/tree/main/src/rust/receiver-mock#statistics
+func parseMetricList(rawMetricsValues string) (MetricCounts, error) {
+	metricNameToCount := make(MetricCounts)
+	lines := strings.Split(rawMetricsValues, "\n")
+	for _, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		// the last colon of the line is the split point
+		splitIndex := strings.LastIndex(line, ":")
+		if splitIndex == -1 || splitIndex == 0 {
+			return nil, fmt.Errorf("failed to parse metrics list line: %q", line)
+		}
+		metricName := line[:splitIndex]
+		metricCountString := strings.TrimSpace(line[splitIndex+1:])
+		metricCount, err := strconv.Atoi(metricCountString)
+		if err != nil {
+			return nil, err
+		}
+		metricNameToCount[metricName] = metricCount
+	}
+	return metricNameToCount, nil
 }
 
 type MetricSample struct {
@@ -88,8 +127,21 @@ func (m MetricsSamplesByTime) Less(i, j int) bool { return m[i].Timestamp > m[j]
 
 type MetadataFilters map[string]string
 
+// GetMetricsSamples fetches metric samples matching metadataFilters. It is a
+// thin convenience wrapper over GetMetricsSamplesQuery for callers that
+// don't need label include/exclude filtering.
 func (client *ReceiverMockClient) GetMetricsSamples(
 	metadataFilters MetadataFilters,
+) ([]MetricSample, error) {
+	return client.GetMetricsSamplesQuery(SampleQuery{Filters: metadataFilters})
+}
+
+// GetMetricsSamplesQuery fetches metric samples matching query.Filters,
+// additionally passing query.LabelInclude/LabelExclude to receiver-mock as
+// query parameters and re-applying them client-side as a safety net so
+// tests only see the labels they actually care about.
+func (client *ReceiverMockClient) GetMetricsSamplesQuery(
+	query SampleQuery,
 ) ([]MetricSample, error) {
 	path, err := url.Parse("metrics-samples")
 	if err != nil {
@@ -98,15 +150,22 @@ func (client *ReceiverMockClient) GetMetricsSamples(
 	u := client.baseUrl.ResolveReference(path)
 
 	q := u.Query()
-	for k, v := range metadataFilters {
+	for k, v := range query.Filters {
 		q.Add(k, v)
 	}
+	for _, pattern := range query.LabelInclude {
+		q.Add("label_include", pattern)
+	}
+	for _, pattern := range query.LabelExclude {
+		q.Add("label_exclude", pattern)
+	}
 	u.RawQuery = q.Encode()
 
-	resp, err := http.Get(u.String())
+	resp, err := client.get(u.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed fetching %s, err: %w", u, err)
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
 		return nil, fmt.Errorf(
@@ -119,30 +178,11 @@ func (client *ReceiverMockClient) GetMetricsSamples(
 	if err := json.NewDecoder(resp.Body).Decode(&metricsSamples); err != nil {
 		return nil, err
 	}
-	return metricsSamples, nil
-}
 
-// parse metrics list returned by /metrics-list
-// https://github.com/SumoLogic/sumologic-kubernetes-tools/tree/main/src/rust/receiver-mock#statistics
-func parseMetricList(rawMetricsValues string) (map[string]int, error) {
-	metricNameToCount := make(map[string]int)
-	lines := strings.Split(rawMetricsValues, "\n")
-	for _, line := range lines {
-		if len(line) == 0 {
-			continue
-		}
-		// the last colon of the line is the split point
-		splitIndex := strings.LastIndex(line, ":")
-		if splitIndex == -1 || splitIndex == 0 {
-			return nil, fmt.Errorf("failed to parse metrics list line: %q", line)
+	if len(query.LabelInclude) > 0 || len(query.LabelExclude) > 0 {
+		for i := range metricsSamples {
+			metricsSamples[i].Labels = filterLabels(metricsSamples[i].Labels, query.LabelInclude, query.LabelExclude)
 		}
-		metricName := line[:splitIndex]
-		metricCountString := strings.TrimSpace(line[splitIndex+1:])
-		metricCount, err := strconv.Atoi(metricCountString)
-		if err != nil {
-			return nil, err
-		}
-		metricNameToCount[metricName] = metricCount
 	}
-	return metricNameToCount, nil
+	return metricsSamples, nil
 }
@@ -0,0 +1,91 @@
+package receivermock
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// retryConfig controls the exponential backoff applied to GETs against
+// receiver-mock.
+type retryConfig struct {
+	attempts     int
+	initialDelay time.Duration
+	maxDelay     time.Duration
+	jitter       bool
+}
+
+// defaultRetryConfig performs no retries, preserving the client's original
+// fail-fast behavior for callers that don't opt in via WithRetry.
+var defaultRetryConfig = retryConfig{attempts: 1}
+
+// defaultMaxDelay is the backoff cap applied when WithRetry is called with
+// maxDelay <= 0. Both get() and the Watch/WatchLogs reconnect loop treat
+// maxDelay <= 0 the same way: "no explicit cap", not "unbounded" - doubling
+// forever would eventually sleep for minutes between polls/reconnects,
+// which defeats the point of retrying at all.
+const defaultMaxDelay = 30 * time.Second
+
+// WithRetry configures client to retry failed GETs with exponential backoff.
+// attempts is the total number of tries (1 means no retry). Connection
+// errors and 5xx responses are treated as retryable; delays double after
+// each attempt, capped at maxDelay (or defaultMaxDelay if maxDelay <= 0),
+// with optional jitter to avoid thundering herds across parallel test runs.
+// The same config also governs the reconnect backoff used by Watch and
+// WatchLogs. It returns client for chaining.
+func (client *ReceiverMockClient) WithRetry(attempts int, initialDelay, maxDelay time.Duration, jitter bool) *ReceiverMockClient {
+	client.retry = retryConfig{
+		attempts:     attempts,
+		initialDelay: initialDelay,
+		maxDelay:     maxDelay,
+		jitter:       jitter,
+	}
+	return client
+}
+
+// get issues a GET request against url, retrying according to client.retry
+// on connection errors and 5xx responses.
+func (client *ReceiverMockClient) get(url string) (*http.Response, error) {
+	attempts := client.retry.attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	maxDelay := client.retry.maxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxDelay
+	}
+
+	delay := client.retry.initialDelay
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(withJitter(delay, client.retry.jitter))
+			delay *= 2
+			if delay > maxDelay {
+				delay = maxDelay
+			}
+		}
+
+		resp, err := http.Get(url)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("received status code %d in response to receiver request at %q", resp.StatusCode, url)
+			resp.Body.Close()
+			continue
+		}
+		return resp, nil
+	}
+	return nil, lastErr
+}
+
+func withJitter(delay time.Duration, jitter bool) time.Duration {
+	if !jitter || delay <= 0 {
+		return delay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay)))
+}
@@ -0,0 +1,147 @@
+package receivermock
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithJitterNoJitterReturnsDelayUnchanged(t *testing.T) {
+	delay := 4 * time.Second
+	if got := withJitter(delay, false); got != delay {
+		t.Errorf("withJitter(%s, false) = %s, want unchanged", delay, got)
+	}
+}
+
+func TestWithJitterNonPositiveDelayReturnsUnchanged(t *testing.T) {
+	if got := withJitter(0, true); got != 0 {
+		t.Errorf("withJitter(0, true) = %s, want 0", got)
+	}
+	if got := withJitter(-time.Second, true); got != -time.Second {
+		t.Errorf("withJitter(-1s, true) = %s, want -1s", got)
+	}
+}
+
+func TestWithJitterStaysWithinBounds(t *testing.T) {
+	delay := 2 * time.Second
+	for i := 0; i < 100; i++ {
+		got := withJitter(delay, true)
+		if got < delay/2 || got >= delay/2+delay {
+			t.Fatalf("withJitter(%s, true) = %s, want within [%s, %s)", delay, got, delay/2, delay/2+delay)
+		}
+	}
+}
+
+// TestGetRetriesOnServerErrorThenSucceeds verifies get() retries a 5xx
+// response according to client.retry.attempts and returns the eventual
+// success, rather than giving up after the first failure.
+func TestGetRetriesOnServerErrorThenSucceeds(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &ReceiverMockClient{
+		retry: retryConfig{attempts: 3, initialDelay: time.Millisecond},
+	}
+
+	resp, err := client.get(server.URL)
+	if err != nil {
+		t.Fatalf("get() returned unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("get() returned status %d, want 200", resp.StatusCode)
+	}
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("server received %d requests, want 3", got)
+	}
+}
+
+// TestGetAttemptsLessThanOneClampsToOne verifies an attempts value below 1
+// (the zero-value retryConfig, or anything misconfigured) still makes at
+// least one request rather than returning an error without trying.
+func TestGetAttemptsLessThanOneClampsToOne(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &ReceiverMockClient{retry: retryConfig{attempts: 0}}
+
+	resp, err := client.get(server.URL)
+	if err != nil {
+		t.Fatalf("get() returned unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+}
+
+// TestGetExhaustsAttemptsAndReturnsLastError verifies get() gives up and
+// surfaces an error once all attempts are spent against a server that
+// never succeeds.
+func TestGetExhaustsAttemptsAndReturnsLastError(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := &ReceiverMockClient{
+		retry: retryConfig{attempts: 3, initialDelay: time.Millisecond},
+	}
+
+	_, err := client.get(server.URL)
+	if err == nil {
+		t.Fatal("get() returned nil error, want error after exhausting attempts")
+	}
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("server received %d requests, want 3", got)
+	}
+}
+
+// TestGetCapsDelayAtMaxDelay verifies the backoff delay never exceeds
+// maxDelay, by checking the total elapsed time stays well under what
+// uncapped doubling would produce.
+func TestGetCapsDelayAtMaxDelay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := &ReceiverMockClient{
+		retry: retryConfig{
+			attempts:     5,
+			initialDelay: 20 * time.Millisecond,
+			maxDelay:     20 * time.Millisecond,
+		},
+	}
+
+	start := time.Now()
+	_, err := client.get(server.URL)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("get() returned nil error, want error")
+	}
+	// Uncapped doubling over 4 sleeps (20ms, 40ms, 80ms, 160ms) would take
+	// 300ms; capped at 20ms each it should take roughly 80ms. Allow generous
+	// headroom for scheduling noise while still catching a missing cap.
+	if elapsed > 200*time.Millisecond {
+		t.Errorf("get() took %s, want well under 200ms with maxDelay capping backoff", elapsed)
+	}
+}
@@ -0,0 +1,104 @@
+package receivermock
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// defaultPollInterval is how often WaitUntil* helpers re-query
+// receiver-mock while waiting for predicate to become true.
+const defaultPollInterval = 500 * time.Millisecond
+
+// WaitUntilMetricsPresent polls GetMetricsSamples with filters until
+// predicate returns true or timeout elapses, returning the last observed
+// samples either way so callers can use them for diagnostics on failure.
+// This replaces the bespoke time.Sleep + assert.Eventually patterns
+// sprinkled through the integration suite.
+func (client *ReceiverMockClient) WaitUntilMetricsPresent(
+	ctx context.Context,
+	filters MetadataFilters,
+	predicate func([]MetricSample) bool,
+	timeout time.Duration,
+) ([]MetricSample, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastSamples []MetricSample
+	for {
+		samples, err := client.GetMetricsSamples(filters)
+		if err == nil {
+			lastSamples = samples
+			if predicate(samples) {
+				return samples, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return lastSamples, fmt.Errorf("timed out after %s waiting for metrics matching %v: %w", timeout, filters, ctx.Err())
+		case <-time.After(defaultPollInterval):
+		}
+	}
+}
+
+// WaitUntilLogsPresent polls GetLogs with filters until predicate returns
+// true or timeout elapses, returning the last observed records either way.
+func (client *ReceiverMockClient) WaitUntilLogsPresent(
+	ctx context.Context,
+	filters MetadataFilters,
+	predicate func([]LogRecord) bool,
+	timeout time.Duration,
+) ([]LogRecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastRecords []LogRecord
+	for {
+		records, err := client.GetLogs(filters)
+		if err == nil {
+			lastRecords = records
+			if predicate(records) {
+				return records, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return lastRecords, fmt.Errorf("timed out after %s waiting for logs matching %v: %w", timeout, filters, ctx.Err())
+		case <-time.After(defaultPollInterval):
+		}
+	}
+}
+
+// WaitUntilMetricCount polls GetMetricCounts until metric has been observed
+// at least count times or timeout elapses, returning the last observed
+// counts either way.
+func (client *ReceiverMockClient) WaitUntilMetricCount(
+	ctx context.Context,
+	t *testing.T,
+	metric string,
+	count int,
+	timeout time.Duration,
+) (MetricCounts, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastCounts MetricCounts
+	for {
+		counts, err := client.GetMetricCounts(t)
+		if err == nil {
+			lastCounts = counts
+			if counts[metric] >= count {
+				return counts, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return lastCounts, fmt.Errorf("timed out after %s waiting for %d samples of %q, observed %d: %w", timeout, count, metric, lastCounts[metric], ctx.Err())
+		case <-time.After(defaultPollInterval):
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package receivermock
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *ReceiverMockClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("failed parsing test server URL: %v", err)
+	}
+	return NewClient(t, *u)
+}
+
+func TestWaitUntilMetricsPresentReturnsAsSoonAsPredicateTrue(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]MetricSample{{Metric: "up", Value: 1}})
+	})
+
+	samples, err := client.WaitUntilMetricsPresent(
+		context.Background(),
+		nil,
+		func(samples []MetricSample) bool { return len(samples) > 0 },
+		time.Second,
+	)
+	if err != nil {
+		t.Fatalf("WaitUntilMetricsPresent returned unexpected error: %v", err)
+	}
+	if len(samples) != 1 || samples[0].Metric != "up" {
+		t.Errorf("WaitUntilMetricsPresent returned %v, want one sample named %q", samples, "up")
+	}
+}
+
+func TestWaitUntilMetricsPresentReturnsErrorOnTimeout(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]MetricSample{})
+	})
+
+	_, err := client.WaitUntilMetricsPresent(
+		context.Background(),
+		nil,
+		func(samples []MetricSample) bool { return len(samples) > 0 },
+		0,
+	)
+	if err == nil {
+		t.Fatal("WaitUntilMetricsPresent returned nil error, want timeout error")
+	}
+}
+
+func TestWaitUntilLogsPresentReturnsAsSoonAsPredicateTrue(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]LogRecord{{Message: "pod started"}})
+	})
+
+	records, err := client.WaitUntilLogsPresent(
+		context.Background(),
+		nil,
+		func(records []LogRecord) bool { return len(records) > 0 },
+		time.Second,
+	)
+	if err != nil {
+		t.Fatalf("WaitUntilLogsPresent returned unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].Message != "pod started" {
+		t.Errorf("WaitUntilLogsPresent returned %v, want one record", records)
+	}
+}
+
+func TestWaitUntilLogsPresentReturnsErrorOnTimeout(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]LogRecord{})
+	})
+
+	_, err := client.WaitUntilLogsPresent(
+		context.Background(),
+		nil,
+		func(records []LogRecord) bool { return len(records) > 0 },
+		0,
+	)
+	if err == nil {
+		t.Fatal("WaitUntilLogsPresent returned nil error, want timeout error")
+	}
+}
+
+func TestWaitUntilMetricCountReturnsOnceCountReached(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("up:3\n"))
+	})
+
+	counts, err := client.WaitUntilMetricCount(context.Background(), t, "up", 3, time.Second)
+	if err != nil {
+		t.Fatalf("WaitUntilMetricCount returned unexpected error: %v", err)
+	}
+	if counts["up"] != 3 {
+		t.Errorf("WaitUntilMetricCount returned counts %v, want up=3", counts)
+	}
+}
+
+func TestWaitUntilMetricCountReturnsErrorOnTimeout(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("up:1\n"))
+	})
+
+	_, err := client.WaitUntilMetricCount(context.Background(), t, "up", 3, 0)
+	if err == nil {
+		t.Fatal("WaitUntilMetricCount returned nil error, want timeout error")
+	}
+}
@@ -0,0 +1,224 @@
+package receivermock
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// watchChannelBuffer bounds how far a slow consumer can fall behind the
+// stream before Watch/WatchLogs start blocking.
+const watchChannelBuffer = 64
+
+// Watch opens a long-lived connection against receiver-mock's streaming
+// metrics endpoint and pushes each new sample matching filters onto the
+// returned channel as it arrives. It reconnects with backoff on transport
+// errors and deduplicates samples by (metric, timestamp, labels) across
+// reconnects so consumers see each sample exactly once. Both channels are
+// closed once ctx is cancelled.
+func (client *ReceiverMockClient) Watch(ctx context.Context, filters MetadataFilters) (<-chan MetricSample, <-chan error) {
+	samples := make(chan MetricSample, watchChannelBuffer)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(samples)
+		defer close(errs)
+
+		seen := make(map[string]struct{})
+		client.streamLoop(ctx, "metrics-stream", filters, errs, func(line []byte) {
+			var sample MetricSample
+			if err := json.Unmarshal(line, &sample); err != nil {
+				return
+			}
+			key := metricDedupeKey(sample)
+			if _, ok := seen[key]; ok {
+				return
+			}
+			seen[key] = struct{}{}
+
+			select {
+			case samples <- sample:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return samples, errs
+}
+
+// WatchLogs opens a long-lived connection against receiver-mock's streaming
+// logs endpoint and pushes each new log record matching filters onto the
+// returned channel as it arrives, with the same reconnect and dedup
+// semantics as Watch.
+func (client *ReceiverMockClient) WatchLogs(ctx context.Context, filters MetadataFilters) (<-chan LogRecord, <-chan error) {
+	records := make(chan LogRecord, watchChannelBuffer)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(records)
+		defer close(errs)
+
+		seen := make(map[string]struct{})
+		client.streamLoop(ctx, "logs-stream", filters, errs, func(line []byte) {
+			var record LogRecord
+			if err := json.Unmarshal(line, &record); err != nil {
+				return
+			}
+			key := logDedupeKey(record)
+			if _, ok := seen[key]; ok {
+				return
+			}
+			seen[key] = struct{}{}
+
+			select {
+			case records <- record:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return records, errs
+}
+
+// labelNames returns the keys of labels in a stable order, used to build a
+// dedup key that doesn't depend on map iteration order.
+func labelNames(labels Labels) []string {
+	names := make([]string, 0, len(labels))
+	for k := range labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// metricDedupeKey identifies a MetricSample by (metric, timestamp, labels)
+// so Watch can recognize the same sample observed again across a
+// reconnect.
+func metricDedupeKey(sample MetricSample) string {
+	return fmt.Sprintf("%s|%d|%s", sample.Metric, sample.Timestamp, labelKeyFor(sample.Labels, labelNames(sample.Labels)))
+}
+
+// logDedupeKey identifies a LogRecord by (message, timestamp, resource
+// attributes) so WatchLogs can recognize the same record observed again
+// across a reconnect.
+func logDedupeKey(record LogRecord) string {
+	resource := Labels(record.Resource)
+	return fmt.Sprintf("%s|%d|%s", record.Message, record.Timestamp, labelKeyFor(resource, labelNames(resource)))
+}
+
+// streamLoop connects to endpoint, feeding each newline-delimited JSON
+// record it receives to onLine, and transparently reconnects with backoff
+// on transport errors until ctx is cancelled.
+func (client *ReceiverMockClient) streamLoop(
+	ctx context.Context,
+	endpoint string,
+	filters MetadataFilters,
+	errs chan<- error,
+	onLine func(line []byte),
+) {
+	minDelay := client.retry.initialDelay
+	if minDelay <= 0 {
+		minDelay = 500 * time.Millisecond
+	}
+	maxDelay := client.retry.maxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxDelay
+	}
+
+	delay := minDelay
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		err := client.streamOnce(ctx, endpoint, filters, onLine)
+		if err != nil {
+			select {
+			case errs <- err:
+			default:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(withJitter(delay, client.retry.jitter)):
+			}
+			delay *= 2
+			if delay > maxDelay {
+				delay = maxDelay
+			}
+			continue
+		}
+
+		// The stream ended cleanly (e.g. the server closed the connection).
+		// Still wait at least minDelay before reconnecting - without a
+		// floor here, a misbehaving endpoint that accepts the connection
+		// and immediately closes it turns this into an unthrottled busy
+		// loop - then reset delay so a genuine error afterwards starts
+		// backing off from the beginning again.
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(withJitter(minDelay, client.retry.jitter)):
+		}
+		delay = minDelay
+	}
+}
+
+func (client *ReceiverMockClient) streamOnce(
+	ctx context.Context,
+	endpoint string,
+	filters MetadataFilters,
+	onLine func(line []byte),
+) error {
+	path, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("failed parsing %s url: %w", endpoint, err)
+	}
+	u := client.baseUrl.ResolveReference(path)
+
+	q := u.Query()
+	for k, v := range filters {
+		q.Add(k, v)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed building request for %s: %w", u, err)
+	}
+
+	resp, err := (&http.Client{Transport: &http.Transport{TLSClientConfig: &client.tlsConfig}}).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed connecting to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("received status code %d connecting to %q", resp.StatusCode, u)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		line := scanner.Bytes()
+		// SSE framing prefixes each payload with "data: "; plain
+		// chunked-JSON-lines payloads don't, so only strip it when present.
+		if len(line) >= 6 && string(line[:6]) == "data: " {
+			line = line[6:]
+		}
+		if len(line) == 0 {
+			continue
+		}
+		onLine(line)
+	}
+	return scanner.Err()
+}
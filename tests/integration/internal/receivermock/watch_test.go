@@ -0,0 +1,49 @@
+package receivermock
+
+import "testing"
+
+func TestMetricDedupeKeyStableAcrossLabelOrder(t *testing.T) {
+	a := MetricSample{
+		Metric:    "container_cpu_usage_seconds_total",
+		Timestamp: 1000,
+		Labels:    Labels{"namespace": "kube-system", "pod": "receiver-mock-0"},
+	}
+	b := MetricSample{
+		Metric:    "container_cpu_usage_seconds_total",
+		Timestamp: 1000,
+		Labels:    Labels{"pod": "receiver-mock-0", "namespace": "kube-system"},
+	}
+
+	if metricDedupeKey(a) != metricDedupeKey(b) {
+		t.Errorf("expected equal dedupe keys for the same sample regardless of label map order")
+	}
+}
+
+func TestMetricDedupeKeyDiffersOnTimestampChange(t *testing.T) {
+	// Reconnects should not dedupe a genuinely new observation just
+	// because it shares metric name and labels with a prior one; only
+	// (metric, timestamp, labels) identifies a sample.
+	a := MetricSample{Metric: "m", Timestamp: 1000, Labels: Labels{"pod": "a"}}
+	b := MetricSample{Metric: "m", Timestamp: 2000, Labels: Labels{"pod": "a"}}
+
+	if metricDedupeKey(a) == metricDedupeKey(b) {
+		t.Errorf("expected different dedupe keys for samples with different timestamps")
+	}
+}
+
+func TestLogDedupeKeyStableAcrossResourceOrder(t *testing.T) {
+	a := LogRecord{
+		Message:   "pod started",
+		Timestamp: 1000,
+		Resource:  ResourceAttributes{"namespace": "kube-system", "pod": "receiver-mock-0"},
+	}
+	b := LogRecord{
+		Message:   "pod started",
+		Timestamp: 1000,
+		Resource:  ResourceAttributes{"pod": "receiver-mock-0", "namespace": "kube-system"},
+	}
+
+	if logDedupeKey(a) != logDedupeKey(b) {
+		t.Errorf("expected equal dedupe keys for the same record regardless of resource map order")
+	}
+}